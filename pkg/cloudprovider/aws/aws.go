@@ -0,0 +1,189 @@
+// Package aws implements cloudprovider.CloudProvider backed by an AWS Auto Scaling Group
+// per nodegroup. A nodegroup's name is expected to be the name of its backing ASG.
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/atlassian/escalator/pkg/cloudprovider"
+)
+
+// CloudProvider implements cloudprovider.CloudProvider against AWS Auto Scaling Groups
+type CloudProvider struct {
+	autoscaling autoscalingiface.AutoScalingAPI
+	ec2         ec2iface.EC2API
+}
+
+var _ cloudprovider.CloudProvider = (*CloudProvider)(nil)
+
+// New creates a CloudProvider using the default AWS session/credential chain
+func New() (*CloudProvider, error) {
+	sess, err := session.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create aws session: %v", err)
+	}
+	return &CloudProvider{autoscaling: autoscaling.New(sess), ec2: ec2.New(sess)}, nil
+}
+
+// IncreaseSize increases the ASG's desired capacity by delta
+func (p *CloudProvider) IncreaseSize(nodegroup string, delta int) error {
+	group, err := p.describeGroup(nodegroup)
+	if err != nil {
+		return err
+	}
+
+	desired := aws.Int64Value(group.DesiredCapacity) + int64(delta)
+	_, err = p.autoscaling.SetDesiredCapacity(&autoscaling.SetDesiredCapacityInput{
+		AutoScalingGroupName: aws.String(nodegroup),
+		DesiredCapacity:      aws.Int64(desired),
+		HonorCooldown:        aws.Bool(false),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set desired capacity on %v: %v", nodegroup, err)
+	}
+	return nil
+}
+
+// DeleteNodes terminates the given nodes' underlying instances, decrementing the ASG's
+// desired capacity so it doesn't immediately replace them. A failure resolving one node's
+// instance ID (for example a node with no ProviderID yet) does not stop the rest of the batch
+// from being terminated; all failures are collected and returned together
+func (p *CloudProvider) DeleteNodes(nodes []*v1.Node) error {
+	var errs []string
+	instanceIDs := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		instanceID, err := p.InstanceID(node)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		instanceIDs = append(instanceIDs, instanceID)
+	}
+	if err := p.TerminateInstances(instanceIDs); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to delete %v of %v nodes: %v", len(errs), len(nodes), strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// TargetSize returns the ASG's desired capacity
+func (p *CloudProvider) TargetSize(nodegroup string) (int, error) {
+	group, err := p.describeGroup(nodegroup)
+	if err != nil {
+		return 0, err
+	}
+	return int(aws.Int64Value(group.DesiredCapacity)), nil
+}
+
+// TemplateNode builds a synthetic node from the instance type of one of the ASG's running
+// instances, for use by estimators/expanders before any matching node actually exists. The
+// instance type's cpu/mem capacity is looked up live via EC2 rather than a hardcoded table, so
+// it isn't limited to a fixed set of instance types
+func (p *CloudProvider) TemplateNode(nodegroup string) (*v1.Node, error) {
+	group, err := p.describeGroup(nodegroup)
+	if err != nil {
+		return nil, err
+	}
+	if len(group.Instances) == 0 {
+		return nil, fmt.Errorf("autoscaling group %v has no instances to template a node from", nodegroup)
+	}
+
+	instanceType := aws.StringValue(group.Instances[0].InstanceType)
+	out, err := p.ec2.DescribeInstanceTypes(&ec2.DescribeInstanceTypesInput{
+		InstanceTypes: []*string{aws.String(instanceType)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe instance type %v: %v", instanceType, err)
+	}
+	if len(out.InstanceTypes) == 0 {
+		return nil, fmt.Errorf("unknown instance type %v for autoscaling group %v", instanceType, nodegroup)
+	}
+
+	info := out.InstanceTypes[0]
+	cpu := aws.Int64Value(info.VCpuInfo.DefaultVCpus)
+	memBytes := aws.Int64Value(info.MemoryInfo.SizeInMiB) * 1024 * 1024
+
+	return &v1.Node{
+		Status: v1.NodeStatus{
+			Capacity: v1.ResourceList{
+				v1.ResourceCPU:    *resource.NewQuantity(cpu, resource.DecimalSI),
+				v1.ResourceMemory: *resource.NewQuantity(memBytes, resource.BinarySI),
+			},
+		},
+	}, nil
+}
+
+// InstanceIDs returns the IDs of the ASG's current instances, independent of whether Kubernetes
+// has registered a Node for any of them yet
+func (p *CloudProvider) InstanceIDs(nodegroup string) ([]string, error) {
+	group, err := p.describeGroup(nodegroup)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(group.Instances))
+	for _, instance := range group.Instances {
+		ids = append(ids, aws.StringValue(instance.InstanceId))
+	}
+	return ids, nil
+}
+
+// InstanceID extracts the instance ID from node's ProviderID, in the same format returned by
+// InstanceIDs
+func (p *CloudProvider) InstanceID(node *v1.Node) (string, error) {
+	return instanceIDFromProviderID(node.Spec.ProviderID)
+}
+
+// TerminateInstances terminates the given instance IDs directly, decrementing the ASG's desired
+// capacity for each one. A failure on one instance does not stop the rest from being terminated;
+// all failures are collected and returned together
+func (p *CloudProvider) TerminateInstances(instanceIDs []string) error {
+	var errs []string
+	for _, instanceID := range instanceIDs {
+		_, err := p.autoscaling.TerminateInstanceInAutoScalingGroup(&autoscaling.TerminateInstanceInAutoScalingGroupInput{
+			InstanceId:                     aws.String(instanceID),
+			ShouldDecrementDesiredCapacity: aws.Bool(true),
+		})
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("failed to terminate instance %v: %v", instanceID, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to terminate %v of %v instances: %v", len(errs), len(instanceIDs), strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func (p *CloudProvider) describeGroup(nodegroup string) (*autoscaling.Group, error) {
+	out, err := p.autoscaling.DescribeAutoScalingGroups(&autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []*string{aws.String(nodegroup)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe autoscaling group %v: %v", nodegroup, err)
+	}
+	if len(out.AutoScalingGroups) == 0 {
+		return nil, fmt.Errorf("autoscaling group %v not found", nodegroup)
+	}
+	return out.AutoScalingGroups[0], nil
+}
+
+// instanceIDFromProviderID extracts the instance ID from a providerID of the form
+// aws:///<az>/<instance-id>
+func instanceIDFromProviderID(providerID string) (string, error) {
+	idx := strings.LastIndex(providerID, "/")
+	if idx == -1 || idx == len(providerID)-1 {
+		return "", fmt.Errorf("invalid aws providerID %q", providerID)
+	}
+	return providerID[idx+1:], nil
+}
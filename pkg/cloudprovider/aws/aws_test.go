@@ -0,0 +1,238 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	v1 "k8s.io/api/core/v1"
+)
+
+// fakeAutoScaling embeds the interface so it satisfies autoscalingiface.AutoScalingAPI without
+// implementing every method; tests only need to stub the handful of calls CloudProvider makes
+type fakeAutoScaling struct {
+	autoscalingiface.AutoScalingAPI
+
+	group                 *autoscaling.Group
+	describeErr           error
+	setDesiredCapacityErr error
+	terminateErr          map[string]error
+	terminatedInstances   []string
+	setDesiredCapacity    *int64
+}
+
+func (f *fakeAutoScaling) DescribeAutoScalingGroups(in *autoscaling.DescribeAutoScalingGroupsInput) (*autoscaling.DescribeAutoScalingGroupsOutput, error) {
+	if f.describeErr != nil {
+		return nil, f.describeErr
+	}
+	if f.group == nil {
+		return &autoscaling.DescribeAutoScalingGroupsOutput{}, nil
+	}
+	return &autoscaling.DescribeAutoScalingGroupsOutput{AutoScalingGroups: []*autoscaling.Group{f.group}}, nil
+}
+
+func (f *fakeAutoScaling) SetDesiredCapacity(in *autoscaling.SetDesiredCapacityInput) (*autoscaling.SetDesiredCapacityOutput, error) {
+	if f.setDesiredCapacityErr != nil {
+		return nil, f.setDesiredCapacityErr
+	}
+	f.setDesiredCapacity = in.DesiredCapacity
+	return &autoscaling.SetDesiredCapacityOutput{}, nil
+}
+
+func (f *fakeAutoScaling) TerminateInstanceInAutoScalingGroup(in *autoscaling.TerminateInstanceInAutoScalingGroupInput) (*autoscaling.TerminateInstanceInAutoScalingGroupOutput, error) {
+	instanceID := aws.StringValue(in.InstanceId)
+	if err, ok := f.terminateErr[instanceID]; ok {
+		return nil, err
+	}
+	f.terminatedInstances = append(f.terminatedInstances, instanceID)
+	return &autoscaling.TerminateInstanceInAutoScalingGroupOutput{}, nil
+}
+
+// fakeEC2 embeds the interface for the same reason as fakeAutoScaling above
+type fakeEC2 struct {
+	ec2iface.EC2API
+
+	instanceTypes map[string]*ec2.InstanceTypeInfo
+	describeErr   error
+}
+
+func (f *fakeEC2) DescribeInstanceTypes(in *ec2.DescribeInstanceTypesInput) (*ec2.DescribeInstanceTypesOutput, error) {
+	if f.describeErr != nil {
+		return nil, f.describeErr
+	}
+	var out []*ec2.InstanceTypeInfo
+	for _, t := range in.InstanceTypes {
+		if info, ok := f.instanceTypes[aws.StringValue(t)]; ok {
+			out = append(out, info)
+		}
+	}
+	return &ec2.DescribeInstanceTypesOutput{InstanceTypes: out}, nil
+}
+
+func testGroup(name string, desired int64, instances ...*autoscaling.Instance) *autoscaling.Group {
+	return &autoscaling.Group{
+		AutoScalingGroupName: aws.String(name),
+		DesiredCapacity:      aws.Int64(desired),
+		Instances:            instances,
+	}
+}
+
+func TestIncreaseSize(t *testing.T) {
+	fake := &fakeAutoScaling{group: testGroup("ng", 3)}
+	p := &CloudProvider{autoscaling: fake}
+
+	if err := p.IncreaseSize("ng", 2); err != nil {
+		t.Fatalf("IncreaseSize() error = %v", err)
+	}
+	if got := aws.Int64Value(fake.setDesiredCapacity); got != 5 {
+		t.Errorf("desired capacity = %v, want 5", got)
+	}
+}
+
+func TestIncreaseSize_DescribeError(t *testing.T) {
+	fake := &fakeAutoScaling{describeErr: fmt.Errorf("boom")}
+	p := &CloudProvider{autoscaling: fake}
+
+	if err := p.IncreaseSize("ng", 2); err == nil {
+		t.Fatal("IncreaseSize() error = nil, want error")
+	}
+}
+
+func TestTargetSize(t *testing.T) {
+	fake := &fakeAutoScaling{group: testGroup("ng", 7)}
+	p := &CloudProvider{autoscaling: fake}
+
+	got, err := p.TargetSize("ng")
+	if err != nil {
+		t.Fatalf("TargetSize() error = %v", err)
+	}
+	if got != 7 {
+		t.Errorf("TargetSize() = %v, want 7", got)
+	}
+}
+
+func TestInstanceIDs(t *testing.T) {
+	fake := &fakeAutoScaling{group: testGroup("ng", 2,
+		&autoscaling.Instance{InstanceId: aws.String("i-1")},
+		&autoscaling.Instance{InstanceId: aws.String("i-2")},
+	)}
+	p := &CloudProvider{autoscaling: fake}
+
+	got, err := p.InstanceIDs("ng")
+	if err != nil {
+		t.Fatalf("InstanceIDs() error = %v", err)
+	}
+	want := []string{"i-1", "i-2"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("InstanceIDs() = %v, want %v", got, want)
+	}
+}
+
+func TestInstanceID(t *testing.T) {
+	p := &CloudProvider{}
+	node := &v1.Node{Spec: v1.NodeSpec{ProviderID: "aws:///us-east-1a/i-abc123"}}
+
+	got, err := p.InstanceID(node)
+	if err != nil {
+		t.Fatalf("InstanceID() error = %v", err)
+	}
+	if got != "i-abc123" {
+		t.Errorf("InstanceID() = %v, want i-abc123", got)
+	}
+
+	if _, err := p.InstanceID(&v1.Node{Spec: v1.NodeSpec{ProviderID: ""}}); err == nil {
+		t.Error("InstanceID() with empty providerID error = nil, want error")
+	}
+}
+
+func TestTerminateInstances(t *testing.T) {
+	fake := &fakeAutoScaling{}
+	p := &CloudProvider{autoscaling: fake}
+
+	if err := p.TerminateInstances([]string{"i-1", "i-2"}); err != nil {
+		t.Fatalf("TerminateInstances() error = %v", err)
+	}
+	if len(fake.terminatedInstances) != 2 {
+		t.Errorf("terminated %v instances, want 2", len(fake.terminatedInstances))
+	}
+}
+
+func TestTerminateInstances_PartialFailure(t *testing.T) {
+	fake := &fakeAutoScaling{terminateErr: map[string]error{"i-2": fmt.Errorf("already terminated")}}
+	p := &CloudProvider{autoscaling: fake}
+
+	err := p.TerminateInstances([]string{"i-1", "i-2", "i-3"})
+	if err == nil {
+		t.Fatal("TerminateInstances() error = nil, want error")
+	}
+	// i-1 and i-3 should still have been terminated despite i-2 failing
+	if len(fake.terminatedInstances) != 2 {
+		t.Errorf("terminated %v instances, want 2 (i-1 and i-3)", len(fake.terminatedInstances))
+	}
+}
+
+func TestDeleteNodes_SkipsNodesWithNoProviderID(t *testing.T) {
+	fake := &fakeAutoScaling{}
+	p := &CloudProvider{autoscaling: fake}
+
+	nodes := []*v1.Node{
+		{Spec: v1.NodeSpec{ProviderID: "aws:///us-east-1a/i-good"}},
+		{Spec: v1.NodeSpec{ProviderID: ""}},
+	}
+
+	err := p.DeleteNodes(nodes)
+	if err == nil {
+		t.Fatal("DeleteNodes() error = nil, want error for the node with no ProviderID")
+	}
+	if len(fake.terminatedInstances) != 1 || fake.terminatedInstances[0] != "i-good" {
+		t.Errorf("terminatedInstances = %v, want [i-good]", fake.terminatedInstances)
+	}
+}
+
+func TestTemplateNode(t *testing.T) {
+	fakeASG := &fakeAutoScaling{group: testGroup("ng", 1,
+		&autoscaling.Instance{InstanceId: aws.String("i-1"), InstanceType: aws.String("m5.large")},
+	)}
+	fakeEC2Client := &fakeEC2{instanceTypes: map[string]*ec2.InstanceTypeInfo{
+		"m5.large": {
+			VCpuInfo:   &ec2.VCpuInfo{DefaultVCpus: aws.Int64(2)},
+			MemoryInfo: &ec2.MemoryInfo{SizeInMiB: aws.Int64(8192)},
+		},
+	}}
+	p := &CloudProvider{autoscaling: fakeASG, ec2: fakeEC2Client}
+
+	node, err := p.TemplateNode("ng")
+	if err != nil {
+		t.Fatalf("TemplateNode() error = %v", err)
+	}
+	if cpu := node.Status.Capacity.Cpu().Value(); cpu != 2 {
+		t.Errorf("cpu capacity = %v, want 2", cpu)
+	}
+	wantMem := int64(8192) * 1024 * 1024
+	if mem := node.Status.Capacity.Memory().Value(); mem != wantMem {
+		t.Errorf("mem capacity = %v, want %v", mem, wantMem)
+	}
+}
+
+func TestTemplateNode_UnknownInstanceType(t *testing.T) {
+	fakeASG := &fakeAutoScaling{group: testGroup("ng", 1,
+		&autoscaling.Instance{InstanceId: aws.String("i-1"), InstanceType: aws.String("mystery.large")},
+	)}
+	p := &CloudProvider{autoscaling: fakeASG, ec2: &fakeEC2{instanceTypes: map[string]*ec2.InstanceTypeInfo{}}}
+
+	if _, err := p.TemplateNode("ng"); err == nil {
+		t.Fatal("TemplateNode() error = nil, want error for unknown instance type")
+	}
+}
+
+func TestTemplateNode_NoInstances(t *testing.T) {
+	p := &CloudProvider{autoscaling: &fakeAutoScaling{group: testGroup("ng", 0)}}
+
+	if _, err := p.TemplateNode("ng"); err == nil {
+		t.Fatal("TemplateNode() error = nil, want error when the ASG has no instances")
+	}
+}
@@ -0,0 +1,28 @@
+// Package cloudprovider lets escalator act on the infrastructure backing a nodegroup
+// directly, rather than only tainting Kubernetes nodes and waiting for the cluster to react.
+package cloudprovider
+
+import v1 "k8s.io/api/core/v1"
+
+// CloudProvider lets escalator manage the nodes backing a nodegroup directly
+type CloudProvider interface {
+	// IncreaseSize increases the target size of the cloud nodegroup by delta
+	IncreaseSize(nodegroup string, delta int) error
+	// DeleteNodes removes the given nodes from their cloud nodegroup
+	DeleteNodes(nodes []*v1.Node) error
+	// TargetSize returns the cloud nodegroup's current target/desired size
+	TargetSize(nodegroup string) (int, error)
+	// TemplateNode returns a representative node for the cloud nodegroup, used to size
+	// pending pods before any real node of that shape exists
+	TemplateNode(nodegroup string) (*v1.Node, error)
+	// InstanceIDs returns the IDs of the instances currently backing nodegroup in the cloud
+	// provider, independent of whether Kubernetes has registered a Node for any of them yet.
+	// Used by the reaper to find instances that never registered a Node at all
+	InstanceIDs(nodegroup string) ([]string, error)
+	// InstanceID extracts node's cloud instance ID from its ProviderID, in the same format
+	// returned by InstanceIDs
+	InstanceID(node *v1.Node) (string, error)
+	// TerminateInstances terminates the given instance IDs directly, for instances that have
+	// no corresponding Kubernetes Node to remove via DeleteNodes
+	TerminateInstances(instanceIDs []string) error
+}
@@ -0,0 +1,51 @@
+package cloudprovider
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Null is a CloudProvider that performs no cloud-side actions, preserving escalator's
+// original behaviour for clusters where the ASG/MIG resizes itself in response to taints
+type Null struct{}
+
+// IncreaseSize implements CloudProvider. It is a no-op: scale up is left to the
+// ASG/MIG reacting on its own
+func (Null) IncreaseSize(nodegroup string, delta int) error {
+	return nil
+}
+
+// DeleteNodes implements CloudProvider. It is a no-op: removal is left to the cluster
+// reacting to the ToBeRemoved taint
+func (Null) DeleteNodes(nodes []*v1.Node) error {
+	return nil
+}
+
+// TargetSize implements CloudProvider. There is no backing nodegroup to query, so it
+// always errors
+func (Null) TargetSize(nodegroup string) (int, error) {
+	return 0, fmt.Errorf("null cloud provider has no target size for nodegroup %v", nodegroup)
+}
+
+// TemplateNode implements CloudProvider. There is no backing nodegroup to template from, so
+// callers should fall back to an existing live node
+func (Null) TemplateNode(nodegroup string) (*v1.Node, error) {
+	return nil, fmt.Errorf("null cloud provider has no template node for nodegroup %v", nodegroup)
+}
+
+// InstanceIDs implements CloudProvider. There is no backing nodegroup to enumerate, so the
+// reaper's unregistered-instance detection is simply a no-op under the null provider
+func (Null) InstanceIDs(nodegroup string) ([]string, error) {
+	return nil, nil
+}
+
+// InstanceID implements CloudProvider. There is no cloud instance to resolve a node back to
+func (Null) InstanceID(node *v1.Node) (string, error) {
+	return "", fmt.Errorf("null cloud provider cannot resolve an instance ID for node %v", node.Name)
+}
+
+// TerminateInstances implements CloudProvider. It is a no-op, for the same reason DeleteNodes is
+func (Null) TerminateInstances(instanceIDs []string) error {
+	return nil
+}
@@ -5,6 +5,11 @@ import (
 	"math"
 	"time"
 
+	"github.com/atlassian/escalator/pkg/cloudprovider"
+	"github.com/atlassian/escalator/pkg/controller/estimator"
+	"github.com/atlassian/escalator/pkg/controller/expander"
+	"github.com/atlassian/escalator/pkg/controller/reaper"
+	"github.com/atlassian/escalator/pkg/health"
 	"github.com/atlassian/escalator/pkg/k8s"
 	"github.com/atlassian/escalator/pkg/metrics"
 	"k8s.io/api/core/v1"
@@ -16,11 +21,19 @@ import (
 
 // Controller contains the core logic of the Autoscaler
 type Controller struct {
-	Client   *Client
-	Opts     *Opts
-	stopChan <-chan struct{}
+	Client        *Client
+	Opts          *Opts
+	CloudProvider cloudprovider.CloudProvider
+	stopChan      <-chan struct{}
 
 	nodeGroups map[string]*NodeGroupState
+
+	// isLeader is 1 if this instance currently holds the leader election lease, 0 otherwise.
+	// Always a pointer so atomic ops are shared across copies of Controller
+	isLeader *int32
+
+	// health tracks RunOnce's liveness for the /health-check endpoint
+	health *health.Checker
 }
 
 // NodeGroupState contains everything about a node group in the current state of the application
@@ -30,6 +43,15 @@ type NodeGroupState struct {
 
 	// used for tracking which nodes are tainted. testing when in dry mode
 	taintTracker []string
+
+	// used to suppress scale down until the relevant ScaleDownDelayAfter* cooldown has elapsed
+	lastScaleUpTime          time.Time
+	lastScaleDownDeleteTime  time.Time
+	lastScaleDownFailureTime time.Time
+
+	// firstSeenUnregistered tracks, by instance ID, how long the reaper has seen a cloud
+	// instance backing this nodegroup with no matching Node, across RunOnce passes
+	firstSeenUnregistered map[string]time.Time
 }
 
 // Opts provide the Controller with config for runtime
@@ -39,6 +61,43 @@ type Opts struct {
 
 	ScanInterval time.Duration
 	DryMode      bool
+
+	// Expander selects which nodegroup to scale up when several nodegroups could
+	// satisfy the same pending pods. Defaults to expander.DefaultExpanderName
+	Expander string
+	// ExpanderPriorities is the ordered list of nodegroup names used by the priority expander
+	ExpanderPriorities []string
+
+	// CloudProvider lets escalator manage the nodes backing a nodegroup directly.
+	// Defaults to cloudprovider.Null, preserving the original taint-only behaviour
+	CloudProvider cloudprovider.CloudProvider
+
+	// LeaderElect enables leader election so only one of several replicas runs the scale loop
+	LeaderElect bool
+	// LeaderElectNamespace is the namespace holding the leader election lease
+	LeaderElectNamespace string
+	// LeaderElectLockName is the name of the leader election lease
+	LeaderElectLockName string
+	// LeaderElectLeaseDuration is how long a leader's lease is valid for
+	LeaderElectLeaseDuration time.Duration
+	// LeaderElectRenewDeadline is how long the leader retries refreshing its lease before giving up
+	LeaderElectRenewDeadline time.Duration
+	// LeaderElectRetryPeriod is how long non-leaders wait between retries of acquiring the lease
+	LeaderElectRetryPeriod time.Duration
+
+	// MaxInactivityTime is how long RunOnce can go without completing successfully before
+	// the /health-check endpoint fails. Defaults to 3x ScanInterval
+	MaxInactivityTime time.Duration
+	// MaxFailingTime is how long RunOnce can fail continuously before the /health-check
+	// endpoint fails, even if it is still completing runs
+	MaxFailingTime time.Duration
+}
+
+// scaleUpCandidate is a nodegroup that scaleNodeGroup decided should scale up, deferred so
+// RunOnce can choose between several candidates via the configured Expander
+type scaleUpCandidate struct {
+	nodegroup string
+	opts      scaleOpts
 }
 
 // scaleOpts provides options for a scale function
@@ -65,16 +124,25 @@ func NewController(opts *Opts, stopChan <-chan struct{}) *Controller {
 	nodegroupMap := make(map[string]*NodeGroupState)
 	for _, nodeGroupOpts := range opts.NodeGroups {
 		nodegroupMap[nodeGroupOpts.Name] = &NodeGroupState{
-			Opts:            nodeGroupOpts,
-			NodeGroupLister: client.Listers[nodeGroupOpts.Name],
+			Opts:                  nodeGroupOpts,
+			NodeGroupLister:       client.Listers[nodeGroupOpts.Name],
+			firstSeenUnregistered: make(map[string]time.Time),
 		}
 	}
 
+	cloudProvider := opts.CloudProvider
+	if cloudProvider == nil {
+		cloudProvider = cloudprovider.Null{}
+	}
+
 	return &Controller{
-		Client:     client,
-		Opts:       opts,
-		stopChan:   stopChan,
-		nodeGroups: nodegroupMap,
+		Client:        client,
+		Opts:          opts,
+		CloudProvider: cloudProvider,
+		stopChan:      stopChan,
+		nodeGroups:    nodegroupMap,
+		isLeader:      new(int32),
+		health:        health.NewChecker(opts.ScanInterval, opts.MaxInactivityTime, opts.MaxFailingTime),
 	}
 }
 
@@ -83,6 +151,26 @@ func (c Controller) dryMode(nodeGroup *NodeGroupState) bool {
 	return c.Opts.DryMode || nodeGroup.Opts.DryMode
 }
 
+// scaleDownCooldownActive returns true if nodeGroup should not scale down right now because a
+// scale up, node deletion, or scale down failure happened too recently
+func scaleDownCooldownActive(nodeGroup *NodeGroupState, now time.Time) bool {
+	delayAfterAdd := nodeGroup.Opts.ScaleDownDelayAfterAdd
+	if delayAfterAdd == 0 {
+		delayAfterAdd = DefaultScaleDownDelayAfterAdd
+	}
+
+	switch {
+	case !nodeGroup.lastScaleUpTime.IsZero() && now.Sub(nodeGroup.lastScaleUpTime) < delayAfterAdd:
+		return true
+	case !nodeGroup.lastScaleDownDeleteTime.IsZero() && now.Sub(nodeGroup.lastScaleDownDeleteTime) < nodeGroup.Opts.ScaleDownDelayAfterDelete:
+		return true
+	case !nodeGroup.lastScaleDownFailureTime.IsZero() && now.Sub(nodeGroup.lastScaleDownFailureTime) < nodeGroup.Opts.ScaleDownDelayAfterFailure:
+		return true
+	default:
+		return false
+	}
+}
+
 // calcPercentUsage helper works out the percentage of cpu and mem for request/capacity
 func calcPercentUsage(cpuR, memR, cpuA, memA resource.Quantity) (float64, float64, error) {
 	if cpuA.MilliValue() == 0 || memA.MilliValue() == 0 {
@@ -93,20 +181,65 @@ func calcPercentUsage(cpuR, memR, cpuA, memA resource.Quantity) (float64, float6
 	return cpuPercent, memPercent, nil
 }
 
-// scaleNodeGroup performs the core logic of calculating util and choosig a scaling action for a node group
-func (c Controller) scaleNodeGroup(nodegroup string, nodeGroup *NodeGroupState) {
+// unschedulablePods filters pods down to the ones that have not yet been scheduled to a node
+func unschedulablePods(pods []*v1.Pod) []*v1.Pod {
+	unschedulable := make([]*v1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" {
+			unschedulable = append(unschedulable, pod)
+		}
+	}
+	return unschedulable
+}
+
+// nodeTemplate picks a representative node for the nodegroup, preferring the CloudProvider's
+// view of the nodegroup's instance type and falling back to guessing from a live node when
+// the CloudProvider can't provide one (e.g. the null provider, or a new empty nodegroup)
+func (c Controller) nodeTemplate(nodegroup string, nodes []*v1.Node) *v1.Node {
+	if template, err := c.CloudProvider.TemplateNode(nodegroup); err == nil {
+		return template
+	}
+	if len(nodes) == 0 {
+		return nil
+	}
+	return nodes[0]
+}
+
+// estimateNodesDelta works out how many nodes are required to schedule the nodegroup's
+// unschedulable pods, using the nodegroup's configured Estimator, capped by the nodegroup's MaxNodes
+func (c Controller) estimateNodesDelta(nodegroup string, nodeGroup *NodeGroupState, untaintedNodes []*v1.Node, pods []*v1.Pod, nodeCount int) int {
+	est, err := estimator.NewEstimator(nodeGroup.Opts.Estimator)
+	if err != nil {
+		log.WithField("nodegroup", nodegroup).Errorf("Failed to create estimator: %v", err)
+		return 0
+	}
+
+	required := est.Estimate(unschedulablePods(pods), c.nodeTemplate(nodegroup, untaintedNodes))
+	if maxAllowed := nodeGroup.Opts.MaxNodes - nodeCount; required > maxAllowed {
+		required = maxAllowed
+	}
+	if required < 0 {
+		required = 0
+	}
+	return required
+}
+
+// scaleNodeGroup performs the core logic of calculating util and choosig a scaling action for a node group.
+// Scale down and no-op actions are performed immediately; a desired scale up is instead returned as a
+// candidate so RunOnce can choose between several nodegroups' candidates via the configured Expander
+func (c Controller) scaleNodeGroup(nodegroup string, nodeGroup *NodeGroupState) (*scaleUpCandidate, bool) {
 	// list all pods
 	pods, err := nodeGroup.Pods.List()
 	if err != nil {
 		log.Errorf("Failed to list pods: %v", err)
-		return
+		return nil, false
 	}
 
 	// List all nodes
 	allNodes, err := nodeGroup.Nodes.List()
 	if err != nil {
 		log.Errorf("Failed to list nodes: %v", err)
-		return
+		return nil, false
 	}
 
 	// Filter into untainted and tainted nodes
@@ -148,7 +281,7 @@ func (c Controller) scaleNodeGroup(nodegroup string, nodeGroup *NodeGroupState)
 	// We assume it is a config error or something bad has gone wrong in the cluster
 	if len(allNodes) == 0 {
 		log.WithField("nodegroup", nodegroup).Warningln("no nodes remaining")
-		return
+		return nil, false
 	}
 	if len(allNodes) < nodeGroup.Opts.MinNodes {
 		log.WithField("nodegroup", nodegroup).Warningf(
@@ -156,7 +289,7 @@ func (c Controller) scaleNodeGroup(nodegroup string, nodeGroup *NodeGroupState)
 			len(allNodes),
 			nodeGroup.Opts.MinNodes,
 		)
-		return
+		return nil, false
 	}
 	if len(allNodes) > nodeGroup.Opts.MaxNodes {
 		log.WithField("nodegroup", nodegroup).Warningf(
@@ -164,19 +297,19 @@ func (c Controller) scaleNodeGroup(nodegroup string, nodeGroup *NodeGroupState)
 			len(allNodes),
 			nodeGroup.Opts.MaxNodes,
 		)
-		return
+		return nil, false
 	}
 
 	// Calc capacity for untainted nodes
 	memRequest, cpuRequest, err := k8s.CalculatePodsRequestsTotal(pods)
 	if err != nil {
 		log.Errorf("Failed to calculate requests: %v", err)
-		return
+		return nil, false
 	}
 	memCapacity, cpuCapacity, err := k8s.CalculateNodesCapacityTotal(untaintedNodes)
 	if err != nil {
 		log.Errorf("Failed to calculate capacity: %v", err)
-		return
+		return nil, false
 	}
 
 	// Metrics
@@ -191,7 +324,7 @@ func (c Controller) scaleNodeGroup(nodegroup string, nodeGroup *NodeGroupState)
 	cpuPercent, memPercent, err := calcPercentUsage(cpuRequest, memRequest, cpuCapacity, memCapacity)
 	if err != nil {
 		log.Errorf("Failed to calculate percentages: %v", err)
-		return
+		return nil, false
 	}
 
 	// Metrics
@@ -215,9 +348,7 @@ func (c Controller) scaleNodeGroup(nodegroup string, nodeGroup *NodeGroupState)
 	// --- Scale Up conditions ---
 	// Need to scale up so capacity can handle requests
 	case maxPercent > nodeGroup.Opts.ScaleUpThreshholdPercent:
-		// TODO(jgonzalez): calculate nodes needed
-		// For now (dev) set it to the config revival rate
-		nodesDelta = nodeGroup.Opts.FastNodeRevivalRate
+		nodesDelta = c.estimateNodesDelta(nodegroup, nodeGroup, untaintedNodes, pods, len(allNodes))
 	}
 
 	log.WithField("nodegroup", nodegroup).Debugln("Delta=", nodesDelta)
@@ -226,7 +357,18 @@ func (c Controller) scaleNodeGroup(nodegroup string, nodeGroup *NodeGroupState)
 	var nodesDeltaResult int
 	switch {
 	case nodesDelta < 0:
-		// Try to scale down
+		// Try to scale down, unless a recent scale up/delete/failure put us in cooldown
+		if scaleDownCooldownActive(nodeGroup, time.Now()) {
+			metrics.NodeGroupScaleDownInCooldown.WithLabelValues(nodegroup).Set(1)
+			log.WithField("nodegroup", nodegroup).Infoln("Skipping scale down: nodegroup is in cooldown")
+			break
+		}
+		metrics.NodeGroupScaleDownInCooldown.WithLabelValues(nodegroup).Set(0)
+
+		// ScaleDown only taints nodesDeltaResult nodes for removal; it doesn't report which
+		// ones, so the CloudProvider can't be told to terminate their instances from here.
+		// Actual instance termination for scale-down happens once TryRemoveTaintedNodes confirms
+		// a tainted node has drained, below
 		nodesDeltaResult, err = c.ScaleDown(scaleOpts{
 			nodes:               allNodes,
 			taintedNodes:        taintedNodes,
@@ -237,25 +379,32 @@ func (c Controller) scaleNodeGroup(nodegroup string, nodeGroup *NodeGroupState)
 			nodesDelta:          -nodesDelta,
 		})
 		if err != nil {
+			nodeGroup.lastScaleDownFailureTime = time.Now()
 			log.WithField("nodegroup", nodegroup).Error(err)
+			return nil, false
 		}
-	case nodesDelta > 0:
-		// Try to scale up
-		nodesDeltaResult, err = c.ScaleUp(scaleOpts{
-			nodes:               allNodes,
-			taintedNodes:        taintedNodes,
-			untaintedNodes:      untaintedNodes,
-			pods:                pods,
-			nodeGroup:           nodeGroup,
-			clusterUsagePercent: maxPercent,
-			nodesDelta:          nodesDelta,
-		})
-		if err != nil {
-			log.WithField("nodegroup", nodegroup).Error(err)
+		if nodesDeltaResult > 0 {
+			nodeGroup.lastScaleDownDeleteTime = time.Now()
 		}
+	case nodesDelta > 0:
+		metrics.NodeGroupScaleDownInCooldown.WithLabelValues(nodegroup).Set(0)
+		// Defer to RunOnce: it picks one winner across all nodegroups that want to scale up
+		return &scaleUpCandidate{
+			nodegroup: nodegroup,
+			opts: scaleOpts{
+				nodes:               allNodes,
+				taintedNodes:        taintedNodes,
+				untaintedNodes:      untaintedNodes,
+				pods:                pods,
+				nodeGroup:           nodeGroup,
+				clusterUsagePercent: maxPercent,
+				nodesDelta:          nodesDelta,
+			},
+		}, true
 	default:
+		metrics.NodeGroupScaleDownInCooldown.WithLabelValues(nodegroup).Set(0)
 		log.WithField("nodegroup", nodegroup).Infoln("No need to scale")
-		removed, err := c.TryRemoveTaintedNodes(scaleOpts{
+		removedNodes, err := c.TryRemoveTaintedNodes(scaleOpts{
 			nodes:               allNodes,
 			taintedNodes:        taintedNodes,
 			untaintedNodes:      untaintedNodes,
@@ -266,31 +415,253 @@ func (c Controller) scaleNodeGroup(nodegroup string, nodeGroup *NodeGroupState)
 		if err != nil {
 			log.WithField("nodegroup", nodegroup).Error(err)
 		}
-		log.WithField("nodegroup", nodegroup).Infoln("There were", removed, "nodes removed this round")
+		if len(removedNodes) > 0 {
+			if err := c.CloudProvider.DeleteNodes(removedNodes); err != nil {
+				log.WithField("nodegroup", nodegroup).Errorf("Failed to terminate instances for removed nodes: %v", err)
+			}
+		}
+		log.WithField("nodegroup", nodegroup).Infoln("There were", len(removedNodes), "nodes removed this round")
 	}
 
 	log.WithField("nodegroup", nodegroup).Debugln("DeltaScaled=", nodesDeltaResult)
+	return nil, true
+}
+
+// resolveScaleUps groups the nodegroups that reported a positive nodesDelta by whether they are
+// actually competing for the same pending pods, and scales each group independently: a group of
+// one scales up directly, while a group of several overlapping nodegroups picks a single winner
+// via the configured Expander, since scaling all of them would only ever schedule the same pods
+// once. It returns the scale up error, if any, for each nodegroup that was actually attempted;
+// nodegroups an expander passed over are not included, since they were never attempted
+func (c Controller) resolveScaleUps(candidates []*scaleUpCandidate) map[string]error {
+	results := make(map[string]error, len(candidates))
+	for _, group := range groupOverlappingCandidates(candidates) {
+		if len(group) == 1 {
+			results[group[0].nodegroup] = c.scaleUpCandidateNow(group[0])
+			continue
+		}
+
+		exp, err := expander.NewExpander(c.Opts.Expander, c.Opts.ExpanderPriorities)
+		if err != nil {
+			log.Errorf("Failed to create expander: %v", err)
+			continue
+		}
+
+		expanderCandidates := make([]expander.Candidate, 0, len(group))
+		for _, candidate := range group {
+			expanderCandidates = append(expanderCandidates, expander.Candidate{
+				NodeGroupName: candidate.nodegroup,
+				Pods:          unschedulablePods(candidate.opts.pods),
+				NodeTemplate:  c.nodeTemplate(candidate.nodegroup, candidate.opts.untaintedNodes),
+			})
+		}
+
+		best := exp.BestOption(expanderCandidates)
+		if best == nil {
+			log.Warningln("Expander did not select a nodegroup to scale up")
+			continue
+		}
+
+		winner := group[0]
+		for _, candidate := range group {
+			if candidate.nodegroup == best.NodeGroupName {
+				winner = candidate
+				break
+			}
+		}
+		for _, candidate := range group {
+			if candidate != winner {
+				log.WithField("nodegroup", candidate.nodegroup).Infoln(
+					"Skipping scale up: expander selected a different nodegroup for the same pending pods",
+				)
+			}
+		}
+		results[winner.nodegroup] = c.scaleUpCandidateNow(winner)
+	}
+	return results
+}
+
+// scaleUpCandidateNow performs the scale up for a single candidate, increasing the backing cloud
+// nodegroup's size to match
+func (c Controller) scaleUpCandidateNow(candidate *scaleUpCandidate) error {
+	result, err := c.ScaleUp(candidate.opts)
+	if err != nil {
+		log.WithField("nodegroup", candidate.nodegroup).Error(err)
+		return err
+	}
+	candidate.opts.nodeGroup.lastScaleUpTime = time.Now()
+	log.WithField("nodegroup", candidate.nodegroup).Debugln("DeltaScaled=", result)
+
+	if err := c.CloudProvider.IncreaseSize(candidate.nodegroup, result); err != nil {
+		log.WithField("nodegroup", candidate.nodegroup).Errorf("Failed to increase cloud nodegroup size: %v", err)
+		return err
+	}
+	return nil
+}
+
+// podKey identifies a pod independently of which nodegroup's lister returned it, so the same
+// pending pod reported by two nodegroups can be recognised as the same pod
+func podKey(pod *v1.Pod) string {
+	return pod.Namespace + "/" + pod.Name
+}
+
+// groupOverlappingCandidates partitions candidates into groups that share at least one pending
+// pod, transitively. Candidates whose pending pods are entirely disjoint from every other
+// candidate's end up alone in their own group and so are never forced to compete for an expander
+func groupOverlappingCandidates(candidates []*scaleUpCandidate) [][]*scaleUpCandidate {
+	// unionFind maps a candidate's index to the index of its group's representative
+	unionFind := make([]int, len(candidates))
+	for i := range unionFind {
+		unionFind[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if unionFind[i] != i {
+			unionFind[i] = find(unionFind[i])
+		}
+		return unionFind[i]
+	}
+	union := func(a, b int) {
+		rootA, rootB := find(a), find(b)
+		if rootA != rootB {
+			unionFind[rootB] = rootA
+		}
+	}
+
+	podOwner := make(map[string]int, len(candidates))
+	for i, candidate := range candidates {
+		for _, pod := range unschedulablePods(candidate.opts.pods) {
+			key := podKey(pod)
+			if owner, ok := podOwner[key]; ok {
+				union(owner, i)
+			} else {
+				podOwner[key] = i
+			}
+		}
+	}
+
+	groups := make(map[int][]*scaleUpCandidate, len(candidates))
+	order := make([]int, 0, len(candidates))
+	for i, candidate := range candidates {
+		root := find(i)
+		if _, ok := groups[root]; !ok {
+			order = append(order, root)
+		}
+		groups[root] = append(groups[root], candidate)
+	}
+
+	result := make([][]*scaleUpCandidate, 0, len(order))
+	for _, root := range order {
+		result = append(result, groups[root])
+	}
+	return result
+}
+
+// reapNodeGroup removes any of a nodegroup's nodes that have been unready for too long, or that
+// registered with the API server but never reported a Ready condition, so the scale loop below
+// sees an accurate node count
+func (c Controller) reapNodeGroup(nodegroup string, nodeGroup *NodeGroupState) {
+	nodes, err := nodeGroup.Nodes.List()
+	if err != nil {
+		log.WithField("nodegroup", nodegroup).Errorf("Failed to list nodes for reaper: %v", err)
+		return
+	}
+
+	unreadyTimeout := nodeGroup.Opts.UnreadyTimeout
+	if unreadyTimeout == 0 {
+		unreadyTimeout = reaper.DefaultUnreadyTimeout
+	}
+	neverReadyTimeout := nodeGroup.Opts.NeverReadyTimeout
+	if neverReadyTimeout == 0 {
+		neverReadyTimeout = reaper.DefaultNeverReadyTimeout
+	}
+	unregisteredTimeout := nodeGroup.Opts.UnregisteredTimeout
+	if unregisteredTimeout == 0 {
+		unregisteredTimeout = reaper.DefaultUnregisteredTimeout
+	}
+
+	r := reaper.New(c.CloudProvider)
+	result := r.Nodes(nodegroup, nodes, unreadyTimeout, neverReadyTimeout, unregisteredTimeout, nodeGroup.firstSeenUnregistered, time.Now())
+
+	if len(result.NotReady) > 0 || len(result.NeverReady) > 0 || len(result.UnregisteredInstanceIDs) > 0 {
+		dryMode := c.dryMode(nodeGroup)
+		if err := r.Reap(result, dryMode); err != nil {
+			log.WithField("nodegroup", nodegroup).Errorf("Failed to reap nodes: %v", err)
+		}
+		log.WithField("nodegroup", nodegroup).Infof(
+			"Reaped %v not-ready, %v never-ready and %v unregistered instances (dry mode: %v)",
+			len(result.NotReady), len(result.NeverReady), len(result.UnregisteredInstanceIDs), dryMode,
+		)
+	}
+
+	metrics.NodeGroupNodesReaped.WithLabelValues(nodegroup).Set(float64(len(result.NotReady)))
+	metrics.NodeGroupNodesNeverReady.WithLabelValues(nodegroup).Set(float64(len(result.NeverReady)))
+	metrics.NodeGroupNodesUnregistered.WithLabelValues(nodegroup).Set(float64(len(result.UnregisteredInstanceIDs)))
 }
 
 // RunOnce performs the main autoscaler logic once
 func (c Controller) RunOnce() {
 	startTime := time.Now()
+	c.health.Start(startTime)
+	success := true
 
-	// TODO(jgonzalez/dangot):
-	// REAPER GOES HERE
+	// Remove any unregistered/long-unready nodes before the scale loop so it sees an
+	// accurate node count
+	for nodegroup, state := range c.nodeGroups {
+		c.reapNodeGroup(nodegroup, state)
+	}
 
-	// Perform the ScaleUp/Taint logic
+	// Perform the ScaleUp/Taint logic. A nodegroup that defers to a scale up candidate isn't
+	// marked successful yet: that only happens once resolveScaleUps has actually attempted it
+	candidates := make([]*scaleUpCandidate, 0, len(c.nodeGroups))
 	for nodegroup, state := range c.nodeGroups {
 		log.Debugln("**********[START NODEGROUP]**********")
-		c.scaleNodeGroup(nodegroup, state)
+		candidate, ok := c.scaleNodeGroup(nodegroup, state)
+		switch {
+		case candidate != nil:
+			candidates = append(candidates, candidate)
+		case ok:
+			c.health.NodeGroupSucceeded(nodegroup, startTime)
+		default:
+			success = false
+		}
+	}
+
+	// Nodegroups an expander passed over in favour of a competing candidate weren't attempted
+	// at all this round, which isn't a failure: only one of a group of overlapping candidates
+	// is ever expected to scale up
+	scaleUpErrs := c.resolveScaleUps(candidates)
+	for _, candidate := range candidates {
+		err, attempted := scaleUpErrs[candidate.nodegroup]
+		if !attempted {
+			continue
+		}
+		if err == nil {
+			c.health.NodeGroupSucceeded(candidate.nodegroup, startTime)
+		} else {
+			success = false
+		}
 	}
 
 	endTime := time.Now()
+	c.health.Finish(endTime, success)
 	log.Debugf("Scaling took a total of %v", endTime.Sub(startTime))
 }
 
-// RunForever starts the autoscaler process and runs once every ScanInterval. blocks thread
+// RunForever starts the autoscaler process and runs once every ScanInterval. blocks thread.
+// If LeaderElect is enabled, RunOnce is only ever invoked while this instance holds the lease
 func (c Controller) RunForever(runImmediately bool) {
+	if !c.Opts.LeaderElect {
+		c.setLeader(true)
+		c.runLoop(runImmediately, c.stopChan)
+		return
+	}
+
+	c.runForeverWithLeaderElection(runImmediately)
+}
+
+// runLoop runs RunOnce once every ScanInterval until stop is closed. blocks thread
+func (c Controller) runLoop(runImmediately bool, stop <-chan struct{}) {
 	if runImmediately {
 		log.Debugln("**********[AUTOSCALER FIRST LOOP]**********")
 		c.RunOnce()
@@ -303,7 +674,7 @@ func (c Controller) RunForever(runImmediately bool) {
 		case <-ticker.C:
 			log.Debugln("**********[AUTOSCALER MAIN LOOP]**********")
 			c.RunOnce()
-		case <-c.stopChan:
+		case <-stop:
 			log.Debugf("Stopping main loop")
 			ticker.Stop()
 			return
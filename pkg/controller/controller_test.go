@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// testPod builds a minimal pod used only to exercise podKey-based grouping
+func testPod(name, namespace string) *v1.Pod {
+	return &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+}
+
+func TestScaleDownCooldownActive(t *testing.T) {
+	now := time.Now()
+
+	cases := []struct {
+		name      string
+		nodeGroup *NodeGroupState
+		want      bool
+	}{
+		{
+			name:      "no recent activity",
+			nodeGroup: &NodeGroupState{Opts: &NodeGroupOptions{}},
+			want:      false,
+		},
+		{
+			name: "recent scale up",
+			nodeGroup: &NodeGroupState{
+				Opts:            &NodeGroupOptions{},
+				lastScaleUpTime: now.Add(-time.Minute),
+			},
+			want: true,
+		},
+		{
+			name: "scale up outside the default delay",
+			nodeGroup: &NodeGroupState{
+				Opts:            &NodeGroupOptions{},
+				lastScaleUpTime: now.Add(-DefaultScaleDownDelayAfterAdd - time.Minute),
+			},
+			want: false,
+		},
+		{
+			name: "recent scale down delete",
+			nodeGroup: &NodeGroupState{
+				Opts:                    &NodeGroupOptions{ScaleDownDelayAfterDelete: time.Hour},
+				lastScaleDownDeleteTime: now.Add(-time.Minute),
+			},
+			want: true,
+		},
+		{
+			name: "recent scale down failure",
+			nodeGroup: &NodeGroupState{
+				Opts:                     &NodeGroupOptions{ScaleDownDelayAfterFailure: time.Hour},
+				lastScaleDownFailureTime: now.Add(-time.Minute),
+			},
+			want: true,
+		},
+	}
+
+	for _, c := range cases {
+		if got := scaleDownCooldownActive(c.nodeGroup, now); got != c.want {
+			t.Errorf("%s: scaleDownCooldownActive() = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestGroupOverlappingCandidates(t *testing.T) {
+	sharedPod := testPod("shared", "ns")
+	aOnlyPod := testPod("a-only", "ns")
+	bOnlyPod := testPod("b-only", "ns")
+
+	a := &scaleUpCandidate{nodegroup: "a", opts: scaleOpts{pods: []*v1.Pod{aOnlyPod, sharedPod}}}
+	b := &scaleUpCandidate{nodegroup: "b", opts: scaleOpts{pods: []*v1.Pod{sharedPod, bOnlyPod}}}
+	c := &scaleUpCandidate{nodegroup: "c", opts: scaleOpts{pods: []*v1.Pod{testPod("c-only", "ns")}}}
+
+	groups := groupOverlappingCandidates([]*scaleUpCandidate{a, b, c})
+
+	if len(groups) != 2 {
+		t.Fatalf("groupOverlappingCandidates() returned %v groups, want 2", len(groups))
+	}
+
+	var sawABTogether, sawCAlone bool
+	for _, group := range groups {
+		if len(group) == 2 {
+			names := map[string]bool{group[0].nodegroup: true, group[1].nodegroup: true}
+			if names["a"] && names["b"] {
+				sawABTogether = true
+			}
+		}
+		if len(group) == 1 && group[0].nodegroup == "c" {
+			sawCAlone = true
+		}
+	}
+	if !sawABTogether {
+		t.Errorf("expected a and b to be grouped together because they share a pending pod")
+	}
+	if !sawCAlone {
+		t.Errorf("expected c to be in its own group since its pending pods are disjoint")
+	}
+}
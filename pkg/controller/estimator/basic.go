@@ -0,0 +1,39 @@
+package estimator
+
+import (
+	"math"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// BasicEstimator sums the requests of the given pods and divides by the
+// capacity of a single node template, rounded up
+type BasicEstimator struct{}
+
+// Estimate returns the number of nodeTemplate-sized nodes required to fit the
+// total cpu/mem requested by pods
+func (e *BasicEstimator) Estimate(pods []*v1.Pod, nodeTemplate *v1.Node) int {
+	if nodeTemplate == nil || len(pods) == 0 {
+		return 0
+	}
+
+	cpuCapacity := nodeTemplate.Status.Capacity.Cpu().MilliValue()
+	memCapacity := nodeTemplate.Status.Capacity.Memory().MilliValue()
+	if cpuCapacity == 0 || memCapacity == 0 {
+		return 0
+	}
+
+	var totalCPU, totalMem int64
+	for _, req := range podRequests(pods) {
+		totalCPU += req.cpu.MilliValue()
+		totalMem += req.mem.MilliValue()
+	}
+
+	nodesForCPU := int(math.Ceil(float64(totalCPU) / float64(cpuCapacity)))
+	nodesForMem := int(math.Ceil(float64(totalMem) / float64(memCapacity)))
+
+	if nodesForCPU > nodesForMem {
+		return nodesForCPU
+	}
+	return nodesForMem
+}
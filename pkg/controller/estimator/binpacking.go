@@ -0,0 +1,62 @@
+package estimator
+
+import (
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// BinpackingEstimator greedily first-fit-decreasing-packs pods, largest
+// request first, onto simulated copies of the node template
+type BinpackingEstimator struct{}
+
+// simulatedNode tracks the remaining capacity of a simulated copy of the
+// node template while packing
+type simulatedNode struct {
+	remainingCPU resource.Quantity
+	remainingMem resource.Quantity
+}
+
+// Estimate returns the number of simulated nodeTemplate-sized nodes needed to
+// fit all of the given pods
+func (e *BinpackingEstimator) Estimate(pods []*v1.Pod, nodeTemplate *v1.Node) int {
+	if nodeTemplate == nil || len(pods) == 0 {
+		return 0
+	}
+
+	cpuCapacity := nodeTemplate.Status.Capacity.Cpu()
+	memCapacity := nodeTemplate.Status.Capacity.Memory()
+	if cpuCapacity.MilliValue() == 0 || memCapacity.MilliValue() == 0 {
+		return 0
+	}
+
+	requests := podRequests(pods)
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].maxCapacityFraction(*cpuCapacity, *memCapacity) > requests[j].maxCapacityFraction(*cpuCapacity, *memCapacity)
+	})
+
+	var nodes []*simulatedNode
+	for _, req := range requests {
+		node := firstFit(nodes, req)
+		if node == nil {
+			node = &simulatedNode{remainingCPU: cpuCapacity.DeepCopy(), remainingMem: memCapacity.DeepCopy()}
+			nodes = append(nodes, node)
+		}
+		node.remainingCPU.Sub(req.cpu)
+		node.remainingMem.Sub(req.mem)
+	}
+
+	return len(nodes)
+}
+
+// firstFit returns the first simulated node the request fits in, or nil if
+// none of them have room
+func firstFit(nodes []*simulatedNode, req podRequest) *simulatedNode {
+	for _, node := range nodes {
+		if req.fits(node.remainingCPU, node.remainingMem) {
+			return node
+		}
+	}
+	return nil
+}
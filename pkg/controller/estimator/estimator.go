@@ -0,0 +1,40 @@
+// Package estimator provides pluggable strategies for calculating how many
+// additional nodes are required to schedule a set of pods onto a node group.
+package estimator
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// BasicEstimatorName is the name of the basic estimator
+	BasicEstimatorName = "basic"
+	// BinpackingEstimatorName is the name of the binpacking estimator
+	BinpackingEstimatorName = "binpacking"
+
+	// DefaultEstimatorName is used when a nodegroup does not specify one
+	DefaultEstimatorName = BasicEstimatorName
+)
+
+// Estimator calculates the number of additional nodes, based on a copy of the
+// nodegroup's node template, required to schedule a set of pods
+type Estimator interface {
+	Estimate(pods []*v1.Pod, nodeTemplate *v1.Node) int
+}
+
+// NewEstimator creates a new Estimator for the given name. An empty name
+// resolves to the default estimator
+func NewEstimator(name string) (Estimator, error) {
+	switch name {
+	case "":
+		return NewEstimator(DefaultEstimatorName)
+	case BasicEstimatorName:
+		return new(BasicEstimator), nil
+	case BinpackingEstimatorName:
+		return new(BinpackingEstimator), nil
+	default:
+		return nil, fmt.Errorf("unknown estimator %q", name)
+	}
+}
@@ -0,0 +1,120 @@
+package estimator
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func testNodeTemplate(cpu, mem string) *v1.Node {
+	return &v1.Node{
+		Status: v1.NodeStatus{
+			Capacity: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse(cpu),
+				v1.ResourceMemory: resource.MustParse(mem),
+			},
+		},
+	}
+}
+
+func testPod(cpu, mem string) *v1.Pod {
+	return &v1.Pod{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Resources: v1.ResourceRequirements{
+						Requests: v1.ResourceList{
+							v1.ResourceCPU:    resource.MustParse(cpu),
+							v1.ResourceMemory: resource.MustParse(mem),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestNewEstimator(t *testing.T) {
+	cases := []struct {
+		name    string
+		want    Estimator
+		wantErr bool
+	}{
+		{"", new(BasicEstimator), false},
+		{BasicEstimatorName, new(BasicEstimator), false},
+		{BinpackingEstimatorName, new(BinpackingEstimator), false},
+		{"unknown", nil, true},
+	}
+	for _, c := range cases {
+		got, err := NewEstimator(c.name)
+		if (err != nil) != c.wantErr {
+			t.Errorf("NewEstimator(%q) error = %v, wantErr %v", c.name, err, c.wantErr)
+			continue
+		}
+		if err == nil && got == nil {
+			t.Errorf("NewEstimator(%q) returned a nil estimator", c.name)
+		}
+	}
+}
+
+func TestBasicEstimator_Estimate(t *testing.T) {
+	e := new(BasicEstimator)
+	node := testNodeTemplate("2", "4Gi")
+
+	if got := e.Estimate(nil, node); got != 0 {
+		t.Errorf("Estimate with no pods = %v, want 0", got)
+	}
+	if got := e.Estimate([]*v1.Pod{testPod("1", "1Gi")}, nil); got != 0 {
+		t.Errorf("Estimate with nil template = %v, want 0", got)
+	}
+
+	pods := []*v1.Pod{
+		testPod("1", "1Gi"),
+		testPod("1", "1Gi"),
+		testPod("1", "1Gi"),
+	}
+	if got := e.Estimate(pods, node); got != 2 {
+		t.Errorf("Estimate() = %v, want 2", got)
+	}
+}
+
+func TestBinpackingEstimator_Estimate(t *testing.T) {
+	e := new(BinpackingEstimator)
+	node := testNodeTemplate("2", "4Gi")
+
+	if got := e.Estimate(nil, node); got != 0 {
+		t.Errorf("Estimate with no pods = %v, want 0", got)
+	}
+
+	// Two pods each take half a node's cpu, so they should pack onto one node
+	pods := []*v1.Pod{
+		testPod("1", "1Gi"),
+		testPod("1", "1Gi"),
+	}
+	if got := e.Estimate(pods, node); got != 1 {
+		t.Errorf("Estimate() = %v, want 1", got)
+	}
+
+	// A cpu-heavy pod and a memory-heavy pod with a larger raw milli value need more cpu
+	// together than one node has, so they can't share a node whichever is packed first
+	pods = []*v1.Pod{
+		testPod("2", "1Gi"),
+		testPod("100m", "3Gi"),
+	}
+	if got := e.Estimate(pods, node); got != 2 {
+		t.Errorf("Estimate() with cpu-heavy pod = %v, want 2", got)
+	}
+}
+
+func TestPodRequest_MaxCapacityFraction(t *testing.T) {
+	cpuCapacity := resource.MustParse("2")
+	memCapacity := resource.MustParse("4Gi")
+
+	requests := podRequests([]*v1.Pod{testPod("2", "1Gi")})
+	// cpu request == full node cpu capacity, so cpu fraction (1.0) must dominate
+	// mem fraction (0.25) even though mem's raw milli value is far larger
+	if got := requests[0].maxCapacityFraction(cpuCapacity, memCapacity); got != 1 {
+		t.Errorf("maxCapacityFraction() = %v, want 1", got)
+	}
+}
@@ -0,0 +1,49 @@
+package estimator
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// podRequest is the total cpu/mem requested across all containers of a pod
+type podRequest struct {
+	cpu resource.Quantity
+	mem resource.Quantity
+}
+
+// podRequests sums the container requests for each pod
+func podRequests(pods []*v1.Pod) []podRequest {
+	requests := make([]podRequest, 0, len(pods))
+	for _, pod := range pods {
+		var req podRequest
+		for _, container := range pod.Spec.Containers {
+			req.cpu.Add(*container.Resources.Requests.Cpu())
+			req.mem.Add(*container.Resources.Requests.Memory())
+		}
+		requests = append(requests, req)
+	}
+	return requests
+}
+
+// maxCapacityFraction returns the larger of the cpu/mem requests expressed as a fraction of the
+// given node capacity, used to rank pods by "size" when binpacking. Comparing raw milli values
+// would always favour memory, since a byte count in milli-units dwarfs a millicore count
+// regardless of how cpu-heavy the pod is
+func (r podRequest) maxCapacityFraction(cpuCapacity, memCapacity resource.Quantity) float64 {
+	var cpuFraction, memFraction float64
+	if capacity := cpuCapacity.MilliValue(); capacity > 0 {
+		cpuFraction = float64(r.cpu.MilliValue()) / float64(capacity)
+	}
+	if capacity := memCapacity.MilliValue(); capacity > 0 {
+		memFraction = float64(r.mem.MilliValue()) / float64(capacity)
+	}
+	if cpuFraction > memFraction {
+		return cpuFraction
+	}
+	return memFraction
+}
+
+// fits returns true if the request fits within the given remaining capacity
+func (r podRequest) fits(remainingCPU, remainingMem resource.Quantity) bool {
+	return remainingCPU.MilliValue() >= r.cpu.MilliValue() && remainingMem.MilliValue() >= r.mem.MilliValue()
+}
@@ -0,0 +1,57 @@
+// Package expander provides pluggable policies for choosing which of several
+// eligible nodegroups should be scaled up to satisfy pending pods.
+package expander
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// RandomExpanderName picks a uniformly random candidate
+	RandomExpanderName = "random"
+	// MostPodsExpanderName picks the candidate whose template schedules the most pods
+	MostPodsExpanderName = "most-pods"
+	// LeastWasteExpanderName picks the candidate leaving the smallest leftover capacity
+	LeastWasteExpanderName = "least-waste"
+	// PriorityExpanderName picks by a user-supplied ordered list of nodegroup names
+	PriorityExpanderName = "priority"
+
+	// DefaultExpanderName is used when no expander is configured. It only affects nodegroups
+	// that are competing for the same pending pods; unrelated nodegroups always scale
+	// independently
+	DefaultExpanderName = RandomExpanderName
+)
+
+// Candidate is a nodegroup that is eligible to be scaled up to satisfy the
+// currently unschedulable pods
+type Candidate struct {
+	NodeGroupName string
+	Pods          []*v1.Pod
+	NodeTemplate  *v1.Node
+}
+
+// Expander picks a single nodegroup to scale up out of several candidates
+type Expander interface {
+	BestOption(candidates []Candidate) *Candidate
+}
+
+// NewExpander creates an Expander for the given name. An empty name resolves
+// to DefaultExpanderName. priorities is only consulted by the priority expander
+func NewExpander(name string, priorities []string) (Expander, error) {
+	switch name {
+	case "":
+		return NewExpander(DefaultExpanderName, priorities)
+	case RandomExpanderName:
+		return new(RandomExpander), nil
+	case MostPodsExpanderName:
+		return new(MostPodsExpander), nil
+	case LeastWasteExpanderName:
+		return new(LeastWasteExpander), nil
+	case PriorityExpanderName:
+		return &PriorityExpander{priorities: priorities}, nil
+	default:
+		return nil, fmt.Errorf("unknown expander %q", name)
+	}
+}
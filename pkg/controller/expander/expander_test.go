@@ -0,0 +1,107 @@
+package expander
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func testNode(cpu, mem string) *v1.Node {
+	return &v1.Node{
+		Status: v1.NodeStatus{
+			Capacity: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse(cpu),
+				v1.ResourceMemory: resource.MustParse(mem),
+			},
+		},
+	}
+}
+
+func testPod(cpu, mem string) *v1.Pod {
+	return &v1.Pod{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Resources: v1.ResourceRequirements{
+						Requests: v1.ResourceList{
+							v1.ResourceCPU:    resource.MustParse(cpu),
+							v1.ResourceMemory: resource.MustParse(mem),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestNewExpander(t *testing.T) {
+	cases := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"", false},
+		{RandomExpanderName, false},
+		{MostPodsExpanderName, false},
+		{LeastWasteExpanderName, false},
+		{PriorityExpanderName, false},
+		{"unknown", true},
+	}
+	for _, c := range cases {
+		exp, err := NewExpander(c.name, nil)
+		if (err != nil) != c.wantErr {
+			t.Errorf("NewExpander(%q) error = %v, wantErr %v", c.name, err, c.wantErr)
+			continue
+		}
+		if err == nil && exp == nil {
+			t.Errorf("NewExpander(%q) returned a nil expander", c.name)
+		}
+	}
+}
+
+func TestMostPodsExpander_BestOption(t *testing.T) {
+	small := Candidate{NodeGroupName: "small", Pods: []*v1.Pod{testPod("1", "1Gi")}, NodeTemplate: testNode("1", "1Gi")}
+	large := Candidate{NodeGroupName: "large", Pods: []*v1.Pod{testPod("1", "1Gi"), testPod("1", "1Gi")}, NodeTemplate: testNode("4", "4Gi")}
+
+	best := new(MostPodsExpander).BestOption([]Candidate{small, large})
+	if best == nil || best.NodeGroupName != "large" {
+		t.Errorf("BestOption() = %+v, want large", best)
+	}
+}
+
+func TestLeastWasteExpander_BestOption(t *testing.T) {
+	tight := Candidate{NodeGroupName: "tight", Pods: []*v1.Pod{testPod("1", "1Gi")}, NodeTemplate: testNode("1", "1Gi")}
+	loose := Candidate{NodeGroupName: "loose", Pods: []*v1.Pod{testPod("1", "1Gi")}, NodeTemplate: testNode("4", "4Gi")}
+
+	best := new(LeastWasteExpander).BestOption([]Candidate{loose, tight})
+	if best == nil || best.NodeGroupName != "tight" {
+		t.Errorf("BestOption() = %+v, want tight", best)
+	}
+}
+
+func TestPriorityExpander_BestOption(t *testing.T) {
+	a := Candidate{NodeGroupName: "a"}
+	b := Candidate{NodeGroupName: "b"}
+
+	e := &PriorityExpander{priorities: []string{"b", "a"}}
+	if best := e.BestOption([]Candidate{a, b}); best == nil || best.NodeGroupName != "b" {
+		t.Errorf("BestOption() = %+v, want b", best)
+	}
+
+	// falls back to the first candidate when nothing matches the priority list
+	e = &PriorityExpander{priorities: []string{"nonexistent"}}
+	if best := e.BestOption([]Candidate{a, b}); best == nil || best.NodeGroupName != "a" {
+		t.Errorf("BestOption() fallback = %+v, want a", best)
+	}
+}
+
+func TestRandomExpander_BestOption(t *testing.T) {
+	if got := new(RandomExpander).BestOption(nil); got != nil {
+		t.Errorf("BestOption(nil) = %+v, want nil", got)
+	}
+
+	a := Candidate{NodeGroupName: "a"}
+	if got := new(RandomExpander).BestOption([]Candidate{a}); got == nil || got.NodeGroupName != "a" {
+		t.Errorf("BestOption() = %+v, want a", got)
+	}
+}
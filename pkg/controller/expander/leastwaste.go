@@ -0,0 +1,18 @@
+package expander
+
+// LeastWasteExpander chooses the candidate that leaves the smallest leftover
+// cpu+mem capacity after packing the pending pods onto its node template
+type LeastWasteExpander struct{}
+
+// BestOption implements Expander
+func (e *LeastWasteExpander) BestOption(candidates []Candidate) *Candidate {
+	var best *Candidate
+	bestWaste := -1.0
+	for i := range candidates {
+		if waste := leftoverPercent(candidates[i].Pods, candidates[i].NodeTemplate); bestWaste < 0 || waste < bestWaste {
+			bestWaste = waste
+			best = &candidates[i]
+		}
+	}
+	return best
+}
@@ -0,0 +1,18 @@
+package expander
+
+// MostPodsExpander chooses the candidate whose node template would schedule
+// the most of the currently-unschedulable pods
+type MostPodsExpander struct{}
+
+// BestOption implements Expander
+func (e *MostPodsExpander) BestOption(candidates []Candidate) *Candidate {
+	var best *Candidate
+	bestCount := -1
+	for i := range candidates {
+		if count := schedulablePodCount(candidates[i].Pods, candidates[i].NodeTemplate); count > bestCount {
+			bestCount = count
+			best = &candidates[i]
+		}
+	}
+	return best
+}
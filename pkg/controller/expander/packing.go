@@ -0,0 +1,75 @@
+package expander
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// podRequest sums the container requests of a single pod
+func podRequest(pod *v1.Pod) (resource.Quantity, resource.Quantity) {
+	var cpu, mem resource.Quantity
+	for _, container := range pod.Spec.Containers {
+		cpu.Add(*container.Resources.Requests.Cpu())
+		mem.Add(*container.Resources.Requests.Memory())
+	}
+	return cpu, mem
+}
+
+// schedulablePodCount greedily packs pods onto a single copy of nodeTemplate and
+// returns how many of them fit
+func schedulablePodCount(pods []*v1.Pod, nodeTemplate *v1.Node) int {
+	if nodeTemplate == nil {
+		return 0
+	}
+	remainingCPU := nodeTemplate.Status.Capacity.Cpu().DeepCopy()
+	remainingMem := nodeTemplate.Status.Capacity.Memory().DeepCopy()
+
+	count := 0
+	for _, pod := range pods {
+		cpu, mem := podRequest(pod)
+		if remainingCPU.MilliValue() >= cpu.MilliValue() && remainingMem.MilliValue() >= mem.MilliValue() {
+			remainingCPU.Sub(cpu)
+			remainingMem.Sub(mem)
+			count++
+		}
+	}
+	return count
+}
+
+// leftoverPercent greedily packs the pods that fit onto a single copy of nodeTemplate and
+// returns the leftover cpu% + mem% (each clamped to [0, 100]) once it is full
+func leftoverPercent(pods []*v1.Pod, nodeTemplate *v1.Node) float64 {
+	if nodeTemplate == nil {
+		return 0
+	}
+	cpuCapacity := nodeTemplate.Status.Capacity.Cpu()
+	memCapacity := nodeTemplate.Status.Capacity.Memory()
+	if cpuCapacity.MilliValue() == 0 || memCapacity.MilliValue() == 0 {
+		return 0
+	}
+
+	remainingCPU := cpuCapacity.DeepCopy()
+	remainingMem := memCapacity.DeepCopy()
+	for _, pod := range pods {
+		cpu, mem := podRequest(pod)
+		if remainingCPU.MilliValue() >= cpu.MilliValue() && remainingMem.MilliValue() >= mem.MilliValue() {
+			remainingCPU.Sub(cpu)
+			remainingMem.Sub(mem)
+		}
+	}
+
+	return clampPercent(remainingCPU.MilliValue(), cpuCapacity.MilliValue()) +
+		clampPercent(remainingMem.MilliValue(), memCapacity.MilliValue())
+}
+
+// clampPercent returns remaining/capacity as a percentage, clamped to [0, 100]
+func clampPercent(remaining, capacity int64) float64 {
+	percent := float64(remaining) / float64(capacity) * 100
+	if percent < 0 {
+		return 0
+	}
+	if percent > 100 {
+		return 100
+	}
+	return percent
+}
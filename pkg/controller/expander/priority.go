@@ -0,0 +1,23 @@
+package expander
+
+// PriorityExpander chooses the first candidate that appears in a user-supplied
+// ordered list of nodegroup names, falling back to the first candidate given
+// if none of the priorities match
+type PriorityExpander struct {
+	priorities []string
+}
+
+// BestOption implements Expander
+func (e *PriorityExpander) BestOption(candidates []Candidate) *Candidate {
+	if len(candidates) == 0 {
+		return nil
+	}
+	for _, name := range e.priorities {
+		for i := range candidates {
+			if candidates[i].NodeGroupName == name {
+				return &candidates[i]
+			}
+		}
+	}
+	return &candidates[0]
+}
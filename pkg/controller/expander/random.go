@@ -0,0 +1,16 @@
+package expander
+
+import "math/rand"
+
+// RandomExpander picks a uniformly random candidate. It is only consulted when several
+// nodegroups are genuinely competing for the same pending pods; nodegroups with disjoint
+// pending pods always scale up independently regardless of which expander is configured
+type RandomExpander struct{}
+
+// BestOption implements Expander
+func (e *RandomExpander) BestOption(candidates []Candidate) *Candidate {
+	if len(candidates) == 0 {
+		return nil
+	}
+	return &candidates[rand.Intn(len(candidates))]
+}
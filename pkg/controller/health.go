@@ -0,0 +1,9 @@
+package controller
+
+import "net/http"
+
+// HealthCheckHandler returns an http.HandlerFunc for the /health-check route, returning 500
+// once RunOnce has gone stale or has been failing continuously for too long
+func (c Controller) HealthCheckHandler() http.HandlerFunc {
+	return c.health.Handler()
+}
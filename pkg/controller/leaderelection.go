@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sync/atomic"
+
+	"github.com/atlassian/escalator/pkg/metrics"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// setLeader atomically records whether this instance currently holds the leader election lease
+func (c Controller) setLeader(isLeader bool) {
+	var value int32
+	if isLeader {
+		value = 1
+	}
+	atomic.StoreInt32(c.isLeader, value)
+
+	var gaugeValue float64
+	if isLeader {
+		gaugeValue = 1
+	}
+	metrics.IsLeader.Set(gaugeValue)
+}
+
+// IsLeader reports whether this instance is allowed to run the scale loop right now. Always
+// true when LeaderElect is disabled
+func (c Controller) IsLeader() bool {
+	return atomic.LoadInt32(c.isLeader) == 1
+}
+
+// HealthzHandler reports 200 OK along with the current leadership status, for use as a
+// Kubernetes liveness/readiness probe endpoint on the metrics HTTP server
+func (c Controller) HealthzHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, "ok (leader: %v)\n", c.IsLeader())
+	}
+}
+
+// runForeverWithLeaderElection blocks running the scale loop only while this instance holds
+// the leader election lease, releasing cleanly when stopChan is closed
+func (c Controller) runForeverWithLeaderElection(runImmediately bool) {
+	identity, err := os.Hostname()
+	if err != nil {
+		log.Fatalf("Failed to determine leader election identity: %v", err)
+	}
+
+	lock, err := resourcelock.New(
+		resourcelock.LeasesResourceLock,
+		c.Opts.LeaderElectNamespace,
+		c.Opts.LeaderElectLockName,
+		c.Opts.K8SClient.CoreV1(),
+		c.Opts.K8SClient.CoordinationV1(),
+		resourcelock.ResourceLockConfig{Identity: identity},
+	)
+	if err != nil {
+		log.Fatalf("Failed to create leader election lock: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-c.stopChan
+		cancel()
+	}()
+
+	leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		LeaseDuration:   c.Opts.LeaderElectLeaseDuration,
+		RenewDeadline:   c.Opts.LeaderElectRenewDeadline,
+		RetryPeriod:     c.Opts.LeaderElectRetryPeriod,
+		ReleaseOnCancel: true,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				log.Infoln("Acquired leader election lease")
+				c.setLeader(true)
+				c.runLoop(runImmediately, ctx.Done())
+			},
+			OnStoppedLeading: func() {
+				log.Infoln("Lost leader election lease")
+				c.setLeader(false)
+			},
+		},
+	})
+}
@@ -0,0 +1,50 @@
+package controller
+
+import "time"
+
+// DefaultScaleDownDelayAfterAdd is how long escalator waits after a scale up before it will
+// consider scaling a nodegroup back down, when ScaleDownDelayAfterAdd is unset
+const DefaultScaleDownDelayAfterAdd = 10 * time.Minute
+
+// NodeGroupOptions represents a nodegroup that escalator is watching and the
+// config options for it
+type NodeGroupOptions struct {
+	Name     string `json:"name"`
+	MinNodes int    `json:"min_nodes"`
+	MaxNodes int    `json:"max_nodes"`
+	DryMode  bool   `json:"dry_mode"`
+
+	ScaleUpThreshholdPercent            int `json:"scale_up_threshold_percent"`
+	TaintUpperCapacityThreshholdPercent int `json:"taint_upper_capacity_threshhold_percent"`
+	TaintLowerCapacityThreshholdPercent int `json:"taint_lower_capacity_threshhold_percent"`
+
+	SlowNodeRemovalRate int `json:"slow_node_removal_rate"`
+	FastNodeRemovalRate int `json:"fast_node_removal_rate"`
+	SlowNodeRevivalRate int `json:"slow_node_revival_rate"`
+	FastNodeRevivalRate int `json:"fast_node_revival_rate"`
+
+	// Estimator selects the strategy used to calculate how many nodes are
+	// required to schedule the pending pods when scaling up. Defaults to
+	// estimator.DefaultEstimatorName
+	Estimator string `json:"estimator"`
+
+	// UnreadyTimeout is how long a node can stay NotReady before the reaper removes it.
+	// Defaults to reaper.DefaultUnreadyTimeout
+	UnreadyTimeout time.Duration `json:"unready_timeout"`
+	// NeverReadyTimeout is how long a registered node can go without ever reporting a Ready
+	// condition before the reaper removes it. Defaults to reaper.DefaultNeverReadyTimeout
+	NeverReadyTimeout time.Duration `json:"never_ready_timeout"`
+	// UnregisteredTimeout is how long a cloud instance can run without a matching Node ever
+	// registering before the reaper terminates it. Defaults to reaper.DefaultUnregisteredTimeout
+	UnregisteredTimeout time.Duration `json:"unregistered_timeout"`
+
+	// ScaleDownDelayAfterAdd is how long to wait after a scale up before scaling down is
+	// considered again. Defaults to DefaultScaleDownDelayAfterAdd
+	ScaleDownDelayAfterAdd time.Duration `json:"scale_down_delay_after_add"`
+	// ScaleDownDelayAfterDelete is how long to wait after a scale down last tainted nodes for
+	// removal before scaling down is considered again
+	ScaleDownDelayAfterDelete time.Duration `json:"scale_down_delay_after_delete"`
+	// ScaleDownDelayAfterFailure is how long to wait after a failed scale down attempt
+	// before scaling down is considered again
+	ScaleDownDelayAfterFailure time.Duration `json:"scale_down_delay_after_failure"`
+}
@@ -0,0 +1,145 @@
+// Package reaper removes nodes that have gone unhealthy and never recovered, that registered
+// with the Kubernetes API server but never went on to report a Ready condition, or that never
+// registered a Node with the API server at all.
+package reaper
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/atlassian/escalator/pkg/cloudprovider"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// DefaultUnreadyTimeout is how long a node can stay NotReady before being reaped
+	DefaultUnreadyTimeout = 15 * time.Minute
+	// DefaultNeverReadyTimeout is how long a node can go without ever reporting a Ready
+	// condition before being reaped
+	DefaultNeverReadyTimeout = 15 * time.Minute
+	// DefaultUnregisteredTimeout is how long a cloud instance can run without a matching Node
+	// ever registering before being reaped
+	DefaultUnregisteredTimeout = 15 * time.Minute
+)
+
+// Reaper finds and removes long-unready or never-ready nodes from their nodegroup
+type Reaper struct {
+	CloudProvider cloudprovider.CloudProvider
+}
+
+// New creates a Reaper backed by the given CloudProvider
+func New(cloudProvider cloudprovider.CloudProvider) *Reaper {
+	return &Reaper{CloudProvider: cloudProvider}
+}
+
+// Result summarises what a Nodes pass found
+type Result struct {
+	NotReady   []*v1.Node
+	NeverReady []*v1.Node
+	// UnregisteredInstanceIDs are cloud instances backing the nodegroup that have had no
+	// matching Node for longer than unregisteredTimeout, i.e. they never registered with the
+	// API server at all
+	UnregisteredInstanceIDs []string
+}
+
+// Nodes returns which of the given nodes have been NotReady for longer than unreadyTimeout, or
+// have reported no Ready condition at all within neverReadyTimeout of being created. It also
+// asks the CloudProvider which instances currently back nodegroup and flags any that have had
+// no matching Node for longer than unregisteredTimeout.
+//
+// firstSeenUnregistered is caller-owned state, keyed by instance ID, used to track how long each
+// instance has been missing its Node across repeated calls; entries for instances that are no
+// longer missing are removed so the map doesn't grow unbounded
+func (r *Reaper) Nodes(
+	nodegroup string,
+	nodes []*v1.Node,
+	unreadyTimeout, neverReadyTimeout, unregisteredTimeout time.Duration,
+	firstSeenUnregistered map[string]time.Time,
+	now time.Time,
+) Result {
+	var result Result
+	registered := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		if instanceID, err := r.CloudProvider.InstanceID(node); err == nil {
+			registered[instanceID] = true
+		}
+
+		condition := readyCondition(node)
+		switch {
+		case condition == nil:
+			if now.Sub(node.CreationTimestamp.Time) > neverReadyTimeout {
+				result.NeverReady = append(result.NeverReady, node)
+			}
+		case condition.Status != v1.ConditionTrue:
+			if now.Sub(condition.LastTransitionTime.Time) > unreadyTimeout {
+				result.NotReady = append(result.NotReady, node)
+			}
+		}
+	}
+
+	instanceIDs, err := r.CloudProvider.InstanceIDs(nodegroup)
+	if err != nil {
+		return result
+	}
+
+	stillUnregistered := make(map[string]bool, len(instanceIDs))
+	for _, instanceID := range instanceIDs {
+		if registered[instanceID] {
+			continue
+		}
+		stillUnregistered[instanceID] = true
+
+		firstSeen, ok := firstSeenUnregistered[instanceID]
+		if !ok {
+			firstSeenUnregistered[instanceID] = now
+			continue
+		}
+		if now.Sub(firstSeen) > unregisteredTimeout {
+			result.UnregisteredInstanceIDs = append(result.UnregisteredInstanceIDs, instanceID)
+		}
+	}
+	for instanceID := range firstSeenUnregistered {
+		if !stillUnregistered[instanceID] {
+			delete(firstSeenUnregistered, instanceID)
+		}
+	}
+
+	return result
+}
+
+// Reap deletes the reaped nodes, and terminates the reaped unregistered instances, via the
+// CloudProvider. In dryMode it performs no deletion, leaving it to the caller to just log/count
+// the result
+func (r *Reaper) Reap(result Result, dryMode bool) error {
+	if dryMode {
+		return nil
+	}
+
+	var errs []string
+	nodes := append(append([]*v1.Node{}, result.NotReady...), result.NeverReady...)
+	if len(nodes) > 0 {
+		if err := r.CloudProvider.DeleteNodes(nodes); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(result.UnregisteredInstanceIDs) > 0 {
+		if err := r.CloudProvider.TerminateInstances(result.UnregisteredInstanceIDs); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%v", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// readyCondition returns the node's Ready condition, or nil if it has never reported one
+func readyCondition(node *v1.Node) *v1.NodeCondition {
+	for i := range node.Status.Conditions {
+		if node.Status.Conditions[i].Type == v1.NodeReady {
+			return &node.Status.Conditions[i]
+		}
+	}
+	return nil
+}
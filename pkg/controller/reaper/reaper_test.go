@@ -0,0 +1,148 @@
+package reaper
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/atlassian/escalator/pkg/cloudprovider"
+)
+
+// fakeCloudProvider records the nodes/instances it was asked to delete, and reports a fixed set
+// of instance IDs and node->instance ID mappings
+type fakeCloudProvider struct {
+	cloudprovider.Null
+	instanceIDs map[string]string // node name -> instance ID
+	backing     []string          // instance IDs currently backing the nodegroup
+
+	deleted    []*v1.Node
+	terminated []string
+}
+
+func (f *fakeCloudProvider) DeleteNodes(nodes []*v1.Node) error {
+	f.deleted = append(f.deleted, nodes...)
+	return nil
+}
+
+func (f *fakeCloudProvider) InstanceIDs(nodegroup string) ([]string, error) {
+	return f.backing, nil
+}
+
+func (f *fakeCloudProvider) InstanceID(node *v1.Node) (string, error) {
+	if id, ok := f.instanceIDs[node.Name]; ok {
+		return id, nil
+	}
+	return "", fmt.Errorf("no instance ID for node %v", node.Name)
+}
+
+func (f *fakeCloudProvider) TerminateInstances(instanceIDs []string) error {
+	f.terminated = append(f.terminated, instanceIDs...)
+	return nil
+}
+
+func nodeWithCondition(name string, status v1.ConditionStatus, transition time.Time) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: v1.NodeStatus{
+			Conditions: []v1.NodeCondition{
+				{Type: v1.NodeReady, Status: status, LastTransitionTime: metav1.NewTime(transition)},
+			},
+		},
+	}
+}
+
+func nodeWithNoCondition(name string, created time.Time) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(created)},
+	}
+}
+
+func TestReaper_Nodes(t *testing.T) {
+	now := time.Now()
+	r := New(&fakeCloudProvider{})
+
+	ready := nodeWithCondition("ready", v1.ConditionTrue, now.Add(-time.Hour))
+	longNotReady := nodeWithCondition("long-not-ready", v1.ConditionFalse, now.Add(-time.Hour))
+	recentlyNotReady := nodeWithCondition("recently-not-ready", v1.ConditionFalse, now.Add(-time.Minute))
+	longNeverReady := nodeWithNoCondition("long-never-ready", now.Add(-time.Hour))
+	recentlyNeverReady := nodeWithNoCondition("recently-never-ready", now.Add(-time.Minute))
+
+	result := r.Nodes(
+		"ng",
+		[]*v1.Node{ready, longNotReady, recentlyNotReady, longNeverReady, recentlyNeverReady},
+		15*time.Minute, 15*time.Minute, 15*time.Minute, map[string]time.Time{}, now,
+	)
+
+	if len(result.NotReady) != 1 || result.NotReady[0].Name != "long-not-ready" {
+		t.Errorf("NotReady = %v, want [long-not-ready]", result.NotReady)
+	}
+	if len(result.NeverReady) != 1 || result.NeverReady[0].Name != "long-never-ready" {
+		t.Errorf("NeverReady = %v, want [long-never-ready]", result.NeverReady)
+	}
+}
+
+func TestReaper_Nodes_UnregisteredInstance(t *testing.T) {
+	now := time.Now()
+	registeredNode := nodeWithCondition("registered", v1.ConditionTrue, now.Add(-time.Hour))
+	provider := &fakeCloudProvider{
+		instanceIDs: map[string]string{"registered": "i-registered"},
+		backing:     []string{"i-registered", "i-orphan"},
+	}
+	r := New(provider)
+
+	firstSeen := map[string]time.Time{}
+
+	// First pass: i-orphan has just been observed missing its Node, so it's not reaped yet
+	result := r.Nodes("ng", []*v1.Node{registeredNode}, 15*time.Minute, 15*time.Minute, 15*time.Minute, firstSeen, now)
+	if len(result.UnregisteredInstanceIDs) != 0 {
+		t.Errorf("UnregisteredInstanceIDs = %v, want none on first observation", result.UnregisteredInstanceIDs)
+	}
+
+	// Second pass, past the timeout: i-orphan is now reaped
+	later := now.Add(16 * time.Minute)
+	result = r.Nodes("ng", []*v1.Node{registeredNode}, 15*time.Minute, 15*time.Minute, 15*time.Minute, firstSeen, later)
+	if len(result.UnregisteredInstanceIDs) != 1 || result.UnregisteredInstanceIDs[0] != "i-orphan" {
+		t.Errorf("UnregisteredInstanceIDs = %v, want [i-orphan]", result.UnregisteredInstanceIDs)
+	}
+
+	// Once it's no longer reported by the cloud provider, its tracking state is forgotten
+	provider.backing = []string{"i-registered"}
+	r.Nodes("ng", []*v1.Node{registeredNode}, 15*time.Minute, 15*time.Minute, 15*time.Minute, firstSeen, later)
+	if _, ok := firstSeen["i-orphan"]; ok {
+		t.Errorf("firstSeen still tracks i-orphan after it stopped being reported")
+	}
+}
+
+func TestReaper_Reap(t *testing.T) {
+	now := time.Now()
+	notReady := nodeWithCondition("not-ready", v1.ConditionFalse, now.Add(-time.Hour))
+	neverReady := nodeWithNoCondition("never-ready", now.Add(-time.Hour))
+	result := Result{
+		NotReady:                []*v1.Node{notReady},
+		NeverReady:              []*v1.Node{neverReady},
+		UnregisteredInstanceIDs: []string{"i-orphan"},
+	}
+
+	provider := &fakeCloudProvider{}
+	r := New(provider)
+
+	if err := r.Reap(result, true); err != nil {
+		t.Fatalf("Reap() in dry mode returned error: %v", err)
+	}
+	if len(provider.deleted) != 0 || len(provider.terminated) != 0 {
+		t.Errorf("dry mode deleted %v nodes and terminated %v instances, want 0 of each", len(provider.deleted), len(provider.terminated))
+	}
+
+	if err := r.Reap(result, false); err != nil {
+		t.Fatalf("Reap() returned error: %v", err)
+	}
+	if len(provider.deleted) != 2 {
+		t.Errorf("deleted %v nodes, want 2", len(provider.deleted))
+	}
+	if len(provider.terminated) != 1 || provider.terminated[0] != "i-orphan" {
+		t.Errorf("terminated = %v, want [i-orphan]", provider.terminated)
+	}
+}
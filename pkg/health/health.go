@@ -0,0 +1,108 @@
+// Package health exposes a /health-check endpoint that reports whether the autoscaler's
+// main loop is still running and succeeding often enough for Kubernetes to trust the
+// process is alive, rather than relying solely on the process still being scheduled.
+package health
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Checker tracks RunOnce's start/finish/success timestamps, overall and per nodegroup, and
+// answers whether the autoscaler should be considered healthy
+type Checker struct {
+	mu sync.RWMutex
+
+	lastStart   time.Time
+	lastFinish  time.Time
+	lastSuccess time.Time
+	nodeGroupOK map[string]time.Time
+
+	// MaxInactivityTime is how long the loop can go without a successful run before
+	// the check fails
+	MaxInactivityTime time.Duration
+	// MaxFailingTime is how long the loop can fail continuously before the check fails,
+	// even if it is still completing runs. Zero disables this check
+	MaxFailingTime time.Duration
+}
+
+// NewChecker creates a Checker. maxInactivityTime defaults to 3x scanInterval when zero
+func NewChecker(scanInterval, maxInactivityTime, maxFailingTime time.Duration) *Checker {
+	if maxInactivityTime == 0 {
+		maxInactivityTime = 3 * scanInterval
+	}
+	return &Checker{
+		nodeGroupOK:       make(map[string]time.Time),
+		MaxInactivityTime: maxInactivityTime,
+		MaxFailingTime:    maxFailingTime,
+	}
+}
+
+// Start records that a RunOnce pass has started
+func (c *Checker) Start(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastStart = now
+}
+
+// Finish records that a RunOnce pass finished, successfully or not
+func (c *Checker) Finish(now time.Time, success bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastFinish = now
+	if success {
+		c.lastSuccess = now
+	}
+}
+
+// NodeGroupSucceeded records that nodegroup was evaluated successfully this round
+func (c *Checker) NodeGroupSucceeded(nodegroup string, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nodeGroupOK[nodegroup] = now
+}
+
+// Healthy reports whether the main loop is healthy, and why not if it isn't
+func (c *Checker) Healthy(now time.Time) (bool, string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.lastSuccess.IsZero() {
+		// Give the autoscaler a chance to complete its first run before failing it
+		return true, "ok: no run has completed yet"
+	}
+
+	if since := now.Sub(c.lastSuccess); since > c.MaxInactivityTime {
+		return false, fmt.Sprintf("no successful run in %v, exceeds MaxInactivityTime of %v", since, c.MaxInactivityTime)
+	}
+
+	if c.MaxFailingTime > 0 && c.lastFinish.After(c.lastSuccess) {
+		if since := now.Sub(c.lastSuccess); since > c.MaxFailingTime {
+			return false, fmt.Sprintf("failing continuously for %v, exceeds MaxFailingTime of %v", since, c.MaxFailingTime)
+		}
+	}
+
+	for nodegroup, lastOK := range c.nodeGroupOK {
+		if since := now.Sub(lastOK); since > c.MaxInactivityTime {
+			return false, fmt.Sprintf("nodegroup %v has not succeeded in %v, exceeds MaxInactivityTime of %v", nodegroup, since, c.MaxInactivityTime)
+		}
+	}
+
+	return true, "ok"
+}
+
+// Handler returns an http.HandlerFunc for the /health-check route, returning 500 when the
+// autoscaler's main loop has gone stale or has been failing continuously
+func (c *Checker) Handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		healthy, reason := c.Healthy(time.Now())
+		if !healthy {
+			w.WriteHeader(http.StatusInternalServerError)
+		} else {
+			w.WriteHeader(http.StatusOK)
+		}
+		fmt.Fprintln(w, reason)
+	}
+}
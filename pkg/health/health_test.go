@@ -0,0 +1,98 @@
+package health
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestChecker_Healthy(t *testing.T) {
+	now := time.Now()
+
+	t.Run("no run has completed yet", func(t *testing.T) {
+		c := NewChecker(time.Minute, 0, 0)
+		if healthy, _ := c.Healthy(now); !healthy {
+			t.Errorf("Healthy() = false, want true before any run completes")
+		}
+	})
+
+	t.Run("recent success", func(t *testing.T) {
+		c := NewChecker(time.Minute, 10*time.Minute, 0)
+		c.Start(now.Add(-time.Second))
+		c.Finish(now, true)
+		if healthy, reason := c.Healthy(now); !healthy {
+			t.Errorf("Healthy() = false (%v), want true", reason)
+		}
+	})
+
+	t.Run("stale success exceeds MaxInactivityTime", func(t *testing.T) {
+		c := NewChecker(time.Minute, 10*time.Minute, 0)
+		c.Finish(now.Add(-time.Hour), true)
+		if healthy, _ := c.Healthy(now); healthy {
+			t.Errorf("Healthy() = true, want false once MaxInactivityTime is exceeded")
+		}
+	})
+
+	t.Run("continuously failing exceeds MaxFailingTime", func(t *testing.T) {
+		c := NewChecker(time.Minute, time.Hour, 5*time.Minute)
+		c.Finish(now.Add(-time.Hour), true)
+		c.Finish(now.Add(-time.Minute), false)
+		if healthy, _ := c.Healthy(now); healthy {
+			t.Errorf("Healthy() = true, want false once MaxFailingTime is exceeded by continuous failures")
+		}
+	})
+
+	t.Run("stale nodegroup exceeds MaxInactivityTime", func(t *testing.T) {
+		c := NewChecker(time.Minute, 10*time.Minute, 0)
+		c.Finish(now, true)
+		c.NodeGroupSucceeded("ng-a", now)
+		c.NodeGroupSucceeded("ng-b", now.Add(-time.Hour))
+		if healthy, reason := c.Healthy(now); healthy {
+			t.Errorf("Healthy() = true (%v), want false once a nodegroup has been stale longer than MaxInactivityTime", reason)
+		}
+	})
+
+	t.Run("all nodegroups recently succeeded", func(t *testing.T) {
+		c := NewChecker(time.Minute, 10*time.Minute, 0)
+		c.Finish(now, true)
+		c.NodeGroupSucceeded("ng-a", now)
+		c.NodeGroupSucceeded("ng-b", now.Add(-time.Second))
+		if healthy, reason := c.Healthy(now); !healthy {
+			t.Errorf("Healthy() = false (%v), want true", reason)
+		}
+	})
+
+	t.Run("MaxInactivityTime defaults to 3x scanInterval", func(t *testing.T) {
+		c := NewChecker(time.Minute, 0, 0)
+		if c.MaxInactivityTime != 3*time.Minute {
+			t.Errorf("MaxInactivityTime = %v, want %v", c.MaxInactivityTime, 3*time.Minute)
+		}
+	})
+}
+
+func TestChecker_Handler(t *testing.T) {
+	c := NewChecker(time.Minute, 10*time.Minute, 0)
+	c.Finish(time.Now(), true)
+
+	req := httptest.NewRequest(http.MethodGet, "/health-check", nil)
+	rec := httptest.NewRecorder()
+	c.Handler()(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("Handler() status = %v, want %v", rec.Code, http.StatusOK)
+	}
+}
+
+func TestChecker_Handler_Unhealthy(t *testing.T) {
+	c := NewChecker(time.Minute, 10*time.Minute, 0)
+	c.Finish(time.Now().Add(-time.Hour), true)
+
+	req := httptest.NewRequest(http.MethodGet, "/health-check", nil)
+	rec := httptest.NewRecorder()
+	c.Handler()(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Handler() status = %v, want %v", rec.Code, http.StatusInternalServerError)
+	}
+}